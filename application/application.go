@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type App struct {
+	router http.Handler
+	rdb    *redis.Client
+	pool   *pgxpool.Pool
+	config Config
+}
+
+func New() *App {
+	app := &App{
+		config: LoadConfig(),
+	}
+
+	switch app.config.Backend {
+	case "postgres":
+		pool, err := pgxpool.New(context.Background(), app.config.PostgresURL)
+		if err != nil {
+			panic(fmt.Sprintf("failed to create postgres pool: %v", err))
+		}
+		app.pool = pool
+		if app.config.CacheEnabled && app.config.RedisAddress != "" {
+			app.rdb = redis.NewClient(&redis.Options{
+				Addr: app.config.RedisAddress,
+			})
+		}
+	default:
+		app.rdb = redis.NewClient(&redis.Options{
+			Addr: app.config.RedisAddress,
+		})
+	}
+
+	app.loadRoutes()
+
+	return app
+}
+
+func (a *App) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", a.config.ServerPort),
+		Handler: a.router,
+	}
+
+	if a.rdb != nil {
+		defer func() {
+			if err := a.rdb.Close(); err != nil {
+				fmt.Println("failed to close redis", err)
+			}
+		}()
+
+		if err := a.rdb.Ping(ctx).Err(); err != nil {
+			return fmt.Errorf("failed to connect to redis: %w", err)
+		}
+	}
+
+	if a.pool != nil {
+		defer a.pool.Close()
+	}
+
+	ch := make(chan error, 1)
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil {
+			ch <- fmt.Errorf("failed to start server: %w", err)
+		}
+		close(ch)
+	}()
+
+	fmt.Println("starting server on port", a.config.ServerPort)
+
+	select {
+	case err := <-ch:
+		return err
+	case <-ctx.Done():
+		timeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return server.Shutdown(timeout)
+	}
+}
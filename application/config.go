@@ -0,0 +1,62 @@
+package application
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config holds the runtime settings for the application, sourced from
+// environment variables with sane local defaults.
+type Config struct {
+	RedisAddress string
+	PostgresURL  string
+	ServerPort   uint16
+	// Backend selects which Repository implementation backs the order
+	// handler: "redis" (default) or "postgres".
+	Backend string
+	// CacheEnabled wraps the order Repository in a CachedRepo backed by
+	// Redis. Requires a Redis connection, so it has no effect when
+	// Backend is "postgres" without a Redis address configured.
+	CacheEnabled bool
+	// CacheTTL is how long a cached order is kept before it must be
+	// re-read from the underlying Repository.
+	CacheTTL time.Duration
+}
+
+func LoadConfig() Config {
+	cfg := Config{
+		RedisAddress: "localhost:6379",
+		PostgresURL:  "postgres://localhost:5432/chi_api",
+		ServerPort:   3000,
+		Backend:      "redis",
+		CacheEnabled: false,
+		CacheTTL:     5 * time.Minute,
+	}
+
+	if redisAddress, exists := os.LookupEnv("REDIS_ADDR"); exists {
+		cfg.RedisAddress = redisAddress
+	}
+
+	if postgresURL, exists := os.LookupEnv("POSTGRES_URL"); exists {
+		cfg.PostgresURL = postgresURL
+	}
+
+	if backend, exists := os.LookupEnv("BACKEND"); exists {
+		cfg.Backend = backend
+	}
+
+	if cacheEnabled, exists := os.LookupEnv("CACHE_ENABLED"); exists {
+		if parsed, err := strconv.ParseBool(cacheEnabled); err == nil {
+			cfg.CacheEnabled = parsed
+		}
+	}
+
+	if cacheTTL, exists := os.LookupEnv("CACHE_TTL"); exists {
+		if parsed, err := time.ParseDuration(cacheTTL); err == nil {
+			cfg.CacheTTL = parsed
+		}
+	}
+
+	return cfg
+}
@@ -0,0 +1,49 @@
+package application
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/victowork/chi-api/handler"
+	"github.com/victowork/chi-api/repository/order"
+)
+
+func (a *App) loadRoutes() {
+	router := chi.NewRouter()
+	router.Use(middleware.Logger)
+
+	router.Route("/orders", a.loadOrderRoutes)
+
+	a.router = router
+}
+
+func (a *App) loadOrderRoutes(router chi.Router) {
+	orderHandler := &handler.Order{
+		Repo: a.orderRepository(),
+	}
+
+	router.Post("/", orderHandler.Create)
+	router.Get("/", orderHandler.List)
+	router.Get("/{id}", orderHandler.GetById)
+	router.Put("/{id}", orderHandler.UpdateById)
+	router.Delete("/{id}", orderHandler.DeleteById)
+}
+
+func (a *App) orderRepository() order.Repository {
+	var repo order.Repository
+	if a.pool != nil {
+		repo = &order.PostgresRepo{Pool: a.pool}
+	} else {
+		repo = &order.RedisRepo{Client: a.rdb}
+	}
+
+	if a.config.CacheEnabled && a.rdb != nil {
+		repo = &order.CachedRepo{
+			Repo:   repo,
+			Client: a.rdb,
+			TTL:    a.config.CacheTTL,
+		}
+	}
+
+	return repo
+}
@@ -2,18 +2,20 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"math/rand"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/victowork/chi-api/model"
 	"github.com/victowork/chi-api/repository/order"
 )
 
 type Order struct {
-	Repo *order.RedisRepo
+	Repo order.Repository
 }
 
 func (o *Order) Create(w http.ResponseWriter, r *http.Request) {
@@ -30,21 +32,23 @@ func (o *Order) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	now := time.Now().UTC()
-	order := model.Order{
-		OrderID:    rand.Uint64(),
+	newOrder := model.Order{
 		CustomerID: body.CustomerID,
 		LineItems:  body.LineItems,
 		CreatedAt:  &now,
 	}
 
-	err := o.Repo.Insert(r.Context(), order)
-	if err != nil {
+	err := o.Repo.Insert(r.Context(), &newOrder)
+	if errors.Is(err, order.ErrAlreadyExists) {
+		w.WriteHeader(http.StatusConflict)
+		return
+	} else if err != nil {
 		fmt.Println("failed to insert order", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	res, err := json.Marshal(order)
+	res, err := json.Marshal(newOrder)
 	if err != nil {
 		fmt.Println("failed to marshal", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -57,16 +61,166 @@ func (o *Order) Create(w http.ResponseWriter, r *http.Request) {
 }
 
 func (o *Order) List(w http.ResponseWriter, r *http.Request) {
-	fmt.Println("List all orders")
+	cursor, err := strconv.ParseUint(r.URL.Query().Get("cursor"), 10, 64)
+	if err != nil {
+		cursor = 0
+	}
+
+	const decimal = 10
+	const bitSize = 64
+	size, err := strconv.ParseUint(r.URL.Query().Get("size"), decimal, bitSize)
+	if err != nil || size == 0 {
+		size = 50
+	}
+
+	page := order.FindAllPage{Offset: cursor, Size: size}
+
+	var res order.FindResults
+
+	switch {
+	case r.URL.Query().Get("customer_id") != "":
+		customerID, parseErr := uuid.Parse(r.URL.Query().Get("customer_id"))
+		if parseErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		res, err = o.Repo.FindByCustomer(r.Context(), customerID, page)
+	case r.URL.Query().Get("status") != "":
+		status := model.OrderStatus(r.URL.Query().Get("status"))
+		res, err = o.Repo.FindByStatus(r.Context(), status, page)
+	default:
+		res, err = o.Repo.FindAll(r.Context(), page)
+	}
+	if err != nil {
+		fmt.Println("failed to find all orders", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var response struct {
+		Items []model.Order `json:"items"`
+		Next  uint64        `json:"next,omitempty"`
+	}
+	response.Items = res.Orders
+	response.Next = res.Cursor
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		fmt.Println("failed to marshal", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Write(data)
 }
+
 func (o *Order) GetById(w http.ResponseWriter, r *http.Request) {
-	fmt.Println("Get an order By Id")
+	idParam := chi.URLParam(r, "id")
+
+	orderID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	theOrder, err := o.Repo.FindByID(r.Context(), orderID)
+	if errors.Is(err, order.ErrNotExits) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	} else if err != nil {
+		fmt.Println("failed to find order by id", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(theOrder); err != nil {
+		fmt.Println("failed to marshal", err)
+		return
+	}
 }
 
 func (o *Order) UpdateById(w http.ResponseWriter, r *http.Request) {
-	fmt.Println("Update an order by Id")
+	var body struct {
+		ShippedAt   *time.Time `json:"shipped_at"`
+		CompletedAt *time.Time `json:"completed_at"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		fmt.Println("error decoding request body", err)
+		return
+	}
+
+	idParam := chi.URLParam(r, "id")
+
+	orderID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	theOrder, err := o.Repo.FindByID(r.Context(), orderID)
+	if errors.Is(err, order.ErrNotExits) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	} else if err != nil {
+		fmt.Println("failed to find order by id", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	switch {
+	case body.ShippedAt != nil && theOrder.ShippedAt != nil:
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("order is already shipped"))
+		return
+	case body.CompletedAt != nil && theOrder.ShippedAt == nil:
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("order must be shipped before it can be completed"))
+		return
+	case body.CompletedAt != nil && theOrder.CompletedAt != nil:
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("order is already completed"))
+		return
+	}
+
+	if body.ShippedAt != nil {
+		theOrder.ShippedAt = body.ShippedAt
+	}
+	if body.CompletedAt != nil {
+		theOrder.CompletedAt = body.CompletedAt
+	}
+
+	if err := o.Repo.Update(r.Context(), theOrder); err != nil {
+		fmt.Println("failed to update order", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(theOrder); err != nil {
+		fmt.Println("failed to marshal", err)
+		return
+	}
 }
 
 func (o *Order) DeleteById(w http.ResponseWriter, r *http.Request) {
-	fmt.Println("Delete an order by Id")
+	idParam := chi.URLParam(r, "id")
+
+	orderID, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := o.Repo.DeleteByID(r.Context(), orderID); errors.Is(err, order.ErrNotExits) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	} else if err != nil {
+		fmt.Println("failed to delete order", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
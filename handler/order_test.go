@@ -0,0 +1,209 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/victowork/chi-api/handler"
+	"github.com/victowork/chi-api/model"
+	"github.com/victowork/chi-api/repository/order"
+)
+
+func newTestOrderHandler(t *testing.T) (*handler.Order, *order.RedisRepo) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	repo := &order.RedisRepo{Client: client}
+
+	return &handler.Order{Repo: repo}, repo
+}
+
+func mountRouter(o *handler.Order) *chi.Mux {
+	r := chi.NewRouter()
+	r.Get("/orders", o.List)
+	r.Get("/orders/{id}", o.GetById)
+	r.Put("/orders/{id}", o.UpdateById)
+	r.Delete("/orders/{id}", o.DeleteById)
+	return r
+}
+
+func TestOrder_GetById(t *testing.T) {
+	t.Parallel()
+
+	o, repo := newTestOrderHandler(t)
+	router := mountRouter(o)
+
+	now := time.Now().UTC()
+	existing := model.Order{
+		CustomerID: uuid.New(),
+		CreatedAt:  &now,
+	}
+	if err := repo.Insert(context.Background(), &existing); err != nil {
+		t.Fatalf("failed to seed order: %v", err)
+	}
+
+	testCases := []struct {
+		name       string
+		id         string
+		wantStatus int
+	}{
+		{name: "existing order", id: "1", wantStatus: http.StatusOK},
+		{name: "missing order", id: "2", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/orders/"+tc.id, nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d", tc.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestOrder_List(t *testing.T) {
+	t.Parallel()
+
+	o, repo := newTestOrderHandler(t)
+	router := mountRouter(o)
+
+	now := time.Now().UTC()
+	for i := 0; i < 3; i++ {
+		if err := repo.Insert(context.Background(), &model.Order{
+			CustomerID: uuid.New(),
+			CreatedAt:  &now,
+		}); err != nil {
+			t.Fatalf("failed to seed order: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/orders?size=10", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var body struct {
+		Items []model.Order `json:"items"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(body.Items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(body.Items))
+	}
+}
+
+func TestOrder_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	o, repo := newTestOrderHandler(t)
+	router := mountRouter(o)
+
+	now := time.Now().UTC()
+	existing := model.Order{
+		CustomerID: uuid.New(),
+		CreatedAt:  &now,
+	}
+	if err := repo.Insert(context.Background(), &existing); err != nil {
+		t.Fatalf("failed to seed order: %v", err)
+	}
+
+	t.Run("cannot complete before shipping", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]any{"completed_at": now})
+		req := httptest.NewRequest(http.MethodPut, "/orders/1", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("ship then complete", func(t *testing.T) {
+		shipBody, _ := json.Marshal(map[string]any{"shipped_at": now})
+		req := httptest.NewRequest(http.MethodPut, "/orders/1", bytes.NewReader(shipBody))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		completeBody, _ := json.Marshal(map[string]any{"completed_at": now})
+		req = httptest.NewRequest(http.MethodPut, "/orders/1", bytes.NewReader(completeBody))
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+	})
+
+	t.Run("missing order", func(t *testing.T) {
+		shipBody, _ := json.Marshal(map[string]any{"shipped_at": now})
+		req := httptest.NewRequest(http.MethodPut, "/orders/99", bytes.NewReader(shipBody))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+		}
+	})
+}
+
+func TestOrder_DeleteById(t *testing.T) {
+	t.Parallel()
+
+	o, repo := newTestOrderHandler(t)
+	router := mountRouter(o)
+
+	now := time.Now().UTC()
+	if err := repo.Insert(context.Background(), &model.Order{
+		CustomerID: uuid.New(),
+		CreatedAt:  &now,
+	}); err != nil {
+		t.Fatalf("failed to seed order: %v", err)
+	}
+
+	testCases := []struct {
+		name       string
+		id         string
+		wantStatus int
+	}{
+		{name: "existing order", id: "1", wantStatus: http.StatusNoContent},
+		{name: "missing order", id: "1", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/orders/"+tc.id, nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d", tc.wantStatus, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Order struct {
+	OrderID     uint64     `json:"order_id"`
+	CustomerID  uuid.UUID  `json:"customer_id"`
+	LineItems   []LineItem `json:"line_items"`
+	CreatedAt   *time.Time `json:"created_at"`
+	ShippedAt   *time.Time `json:"shipped_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+type OrderStatus string
+
+const (
+	StatusCreated   OrderStatus = "created"
+	StatusShipped   OrderStatus = "shipped"
+	StatusCompleted OrderStatus = "completed"
+)
+
+// Status derives the order's current status from its timestamps rather
+// than storing it redundantly.
+func (o Order) Status() OrderStatus {
+	switch {
+	case o.CompletedAt != nil:
+		return StatusCompleted
+	case o.ShippedAt != nil:
+		return StatusShipped
+	default:
+		return StatusCreated
+	}
+}
+
+type LineItem struct {
+	ItemID   uuid.UUID `json:"item_id"`
+	Quantity uint      `json:"quantity"`
+	Price    uint      `json:"price"`
+}
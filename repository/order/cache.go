@@ -0,0 +1,94 @@
+package order
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/victowork/chi-api/model"
+)
+
+// CachedRepo decorates a Repository with a read-through cache: FindByID
+// checks Redis first, falling back to Repo on a miss and populating the
+// cache on success. Orders are gob-encoded rather than JSON, since the
+// cache only ever round-trips through this package and gob is cheaper to
+// decode. Update and DeleteByID invalidate the cached entry so callers
+// never observe a stale order after a write.
+type CachedRepo struct {
+	Repo   Repository
+	Client *redis.Client
+	TTL    time.Duration
+}
+
+func cacheKey(id uint64) string {
+	return fmt.Sprintf("cache:order:%d", id)
+}
+
+func (r *CachedRepo) Insert(ctx context.Context, order *model.Order) error {
+	return r.Repo.Insert(ctx, order)
+}
+
+func (r *CachedRepo) FindByID(ctx context.Context, id uint64) (model.Order, error) {
+	key := cacheKey(id)
+
+	if value, err := r.Client.Get(ctx, key).Bytes(); err == nil {
+		var order model.Order
+		if err := gob.NewDecoder(bytes.NewReader(value)).Decode(&order); err == nil {
+			return order, nil
+		}
+	}
+
+	order, err := r.Repo.FindByID(ctx, id)
+	if err != nil {
+		return model.Order{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(order); err == nil {
+		if err := r.Client.Set(ctx, key, buf.Bytes(), r.TTL).Err(); err != nil {
+			fmt.Println("failed to populate order cache", err)
+		}
+	}
+
+	return order, nil
+}
+
+func (r *CachedRepo) Update(ctx context.Context, order model.Order) error {
+	if err := r.Repo.Update(ctx, order); err != nil {
+		return err
+	}
+
+	if err := r.Client.Del(ctx, cacheKey(order.OrderID)).Err(); err != nil {
+		fmt.Println("failed to invalidate order cache", err)
+	}
+
+	return nil
+}
+
+func (r *CachedRepo) DeleteByID(ctx context.Context, id uint64) error {
+	if err := r.Repo.DeleteByID(ctx, id); err != nil {
+		return err
+	}
+
+	if err := r.Client.Del(ctx, cacheKey(id)).Err(); err != nil {
+		fmt.Println("failed to invalidate order cache", err)
+	}
+
+	return nil
+}
+
+func (r *CachedRepo) FindAll(ctx context.Context, page FindAllPage) (FindResults, error) {
+	return r.Repo.FindAll(ctx, page)
+}
+
+func (r *CachedRepo) FindByCustomer(ctx context.Context, customerID uuid.UUID, page FindAllPage) (FindResults, error) {
+	return r.Repo.FindByCustomer(ctx, customerID, page)
+}
+
+func (r *CachedRepo) FindByStatus(ctx context.Context, status model.OrderStatus, page FindAllPage) (FindResults, error) {
+	return r.Repo.FindByStatus(ctx, status, page)
+}
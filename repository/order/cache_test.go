@@ -0,0 +1,150 @@
+package order_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/victowork/chi-api/model"
+	"github.com/victowork/chi-api/repository/order"
+)
+
+func newTestCachedRepo(t *testing.T) (*order.CachedRepo, *order.RedisRepo) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	repo := &order.RedisRepo{Client: client}
+	return &order.CachedRepo{Repo: repo, Client: client, TTL: time.Minute}, repo
+}
+
+func TestCachedRepo_FindByID_PopulatesOnMiss(t *testing.T) {
+	t.Parallel()
+
+	cached, repo := newTestCachedRepo(t)
+
+	now := time.Now().UTC()
+	seeded := model.Order{CustomerID: uuid.New(), CreatedAt: &now}
+	if err := repo.Insert(context.Background(), &seeded); err != nil {
+		t.Fatalf("failed to seed order: %v", err)
+	}
+
+	found, err := cached.FindByID(context.Background(), seeded.OrderID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found.OrderID != seeded.OrderID {
+		t.Fatalf("expected order %d, got %d", seeded.OrderID, found.OrderID)
+	}
+
+	if err := repo.DeleteByID(context.Background(), seeded.OrderID); err != nil {
+		t.Fatalf("failed to delete underlying order: %v", err)
+	}
+
+	found, err = cached.FindByID(context.Background(), seeded.OrderID)
+	if err != nil {
+		t.Fatalf("expected cached hit despite deleted underlying order, got error: %v", err)
+	}
+	if found.OrderID != seeded.OrderID {
+		t.Fatalf("expected cached order %d, got %d", seeded.OrderID, found.OrderID)
+	}
+}
+
+func TestCachedRepo_Update_InvalidatesCache(t *testing.T) {
+	t.Parallel()
+
+	cached, repo := newTestCachedRepo(t)
+
+	now := time.Now().UTC()
+	seeded := model.Order{CustomerID: uuid.New(), CreatedAt: &now}
+	if err := repo.Insert(context.Background(), &seeded); err != nil {
+		t.Fatalf("failed to seed order: %v", err)
+	}
+
+	if _, err := cached.FindByID(context.Background(), seeded.OrderID); err != nil {
+		t.Fatalf("failed to warm cache: %v", err)
+	}
+
+	seeded.ShippedAt = &now
+	if err := cached.Update(context.Background(), seeded); err != nil {
+		t.Fatalf("failed to update order: %v", err)
+	}
+
+	found, err := cached.FindByID(context.Background(), seeded.OrderID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found.ShippedAt == nil {
+		t.Fatal("expected FindByID to reflect the update, got stale cached order")
+	}
+}
+
+func TestCachedRepo_DeleteByID_InvalidatesCache(t *testing.T) {
+	t.Parallel()
+
+	cached, repo := newTestCachedRepo(t)
+
+	now := time.Now().UTC()
+	seeded := model.Order{CustomerID: uuid.New(), CreatedAt: &now}
+	if err := repo.Insert(context.Background(), &seeded); err != nil {
+		t.Fatalf("failed to seed order: %v", err)
+	}
+
+	if _, err := cached.FindByID(context.Background(), seeded.OrderID); err != nil {
+		t.Fatalf("failed to warm cache: %v", err)
+	}
+
+	if err := cached.DeleteByID(context.Background(), seeded.OrderID); err != nil {
+		t.Fatalf("failed to delete order: %v", err)
+	}
+
+	if _, err := cached.FindByID(context.Background(), seeded.OrderID); err != order.ErrNotExits {
+		t.Fatalf("expected ErrNotExits after invalidated delete, got %v", err)
+	}
+}
+
+func BenchmarkFindByID(b *testing.B) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		b.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	repo := &order.RedisRepo{Client: client}
+	cached := &order.CachedRepo{Repo: repo, Client: client, TTL: time.Minute}
+
+	now := time.Now().UTC()
+	seeded := model.Order{CustomerID: uuid.New(), CreatedAt: &now}
+	if err := repo.Insert(context.Background(), &seeded); err != nil {
+		b.Fatalf("failed to seed order: %v", err)
+	}
+
+	b.Run("Uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := repo.FindByID(context.Background(), seeded.OrderID); err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+
+	b.Run("Cached", func(b *testing.B) {
+		if _, err := cached.FindByID(context.Background(), seeded.OrderID); err != nil {
+			b.Fatalf("failed to warm cache: %v", err)
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := cached.FindByID(context.Background(), seeded.OrderID); err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/victowork/chi-api/model"
+)
+
+// PostgresRepo is a durable Repository implementation backed by Postgres,
+// used in place of RedisRepo when application.Config.Backend is "postgres".
+// It expects the orders table created by migrations/0001_create_orders_table.up.sql.
+type PostgresRepo struct {
+	Pool *pgxpool.Pool
+}
+
+func (r *PostgresRepo) Insert(ctx context.Context, order *model.Order) error {
+	lineItems, err := json.Marshal(order.LineItems)
+	if err != nil {
+		return fmt.Errorf("failed to encode line items: %w", err)
+	}
+
+	const query = `
+		INSERT INTO orders (customer_id, line_items, created_at, shipped_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING order_id
+	`
+
+	err = r.Pool.QueryRow(ctx, query,
+		order.CustomerID, lineItems, order.CreatedAt, order.ShippedAt, order.CompletedAt).Scan(&order.OrderID)
+	if err != nil {
+		return fmt.Errorf("failed to insert order into postgres: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresRepo) FindByID(ctx context.Context, id uint64) (model.Order, error) {
+	const query = `
+		SELECT order_id, customer_id, line_items, created_at, shipped_at, completed_at
+		FROM orders WHERE order_id = $1
+	`
+
+	row := r.Pool.QueryRow(ctx, query, id)
+
+	order, err := scanOrder(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Order{}, ErrNotExits
+	} else if err != nil {
+		return model.Order{}, fmt.Errorf("failed to find order by id in postgres: %w", err)
+	}
+
+	return order, nil
+}
+
+func (r *PostgresRepo) Update(ctx context.Context, order model.Order) error {
+	lineItems, err := json.Marshal(order.LineItems)
+	if err != nil {
+		return fmt.Errorf("failed to encode line items: %w", err)
+	}
+
+	const query = `
+		UPDATE orders
+		SET customer_id = $2, line_items = $3, created_at = $4, shipped_at = $5, completed_at = $6
+		WHERE order_id = $1
+	`
+
+	tag, err := r.Pool.Exec(ctx, query,
+		order.OrderID, order.CustomerID, lineItems, order.CreatedAt, order.ShippedAt, order.CompletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update order in postgres: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotExits
+	}
+
+	return nil
+}
+
+func (r *PostgresRepo) DeleteByID(ctx context.Context, id uint64) error {
+	const query = `DELETE FROM orders WHERE order_id = $1`
+
+	tag, err := r.Pool.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete order from postgres: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotExits
+	}
+
+	return nil
+}
+
+func (r *PostgresRepo) FindAll(ctx context.Context, page FindAllPage) (FindResults, error) {
+	return r.findAllWhere(ctx, "TRUE", page)
+}
+
+func (r *PostgresRepo) FindByCustomer(ctx context.Context, customerID uuid.UUID, page FindAllPage) (FindResults, error) {
+	return r.findAllWhere(ctx, "customer_id = $3", page, customerID)
+}
+
+const statusExpr = `
+	CASE
+		WHEN completed_at IS NOT NULL THEN 'completed'
+		WHEN shipped_at IS NOT NULL THEN 'shipped'
+		ELSE 'created'
+	END
+`
+
+func (r *PostgresRepo) FindByStatus(ctx context.Context, status model.OrderStatus, page FindAllPage) (FindResults, error) {
+	return r.findAllWhere(ctx, statusExpr+" = $3", page, status)
+}
+
+func (r *PostgresRepo) findAllWhere(ctx context.Context, where string, page FindAllPage, args ...any) (FindResults, error) {
+	query := fmt.Sprintf(`
+		SELECT order_id, customer_id, line_items, created_at, shipped_at, completed_at
+		FROM orders
+		WHERE %s
+		ORDER BY order_id
+		OFFSET $1 LIMIT $2
+	`, where)
+
+	rows, err := r.Pool.Query(ctx, query, append([]any{page.Offset, page.Size}, args...)...)
+	if err != nil {
+		return FindResults{}, fmt.Errorf("failed to find orders in postgres: %w", err)
+	}
+	defer rows.Close()
+
+	orders := []model.Order{}
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return FindResults{}, fmt.Errorf("failed to scan order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		return FindResults{}, fmt.Errorf("failed to find orders in postgres: %w", err)
+	}
+
+	var cursor uint64
+	if len(orders) > 0 {
+		cursor = page.Offset + uint64(len(orders))
+	}
+
+	return FindResults{
+		Orders: orders,
+		Cursor: cursor,
+	}, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanOrder(row rowScanner) (model.Order, error) {
+	var order model.Order
+	var lineItems []byte
+
+	err := row.Scan(
+		&order.OrderID, &order.CustomerID, &lineItems,
+		&order.CreatedAt, &order.ShippedAt, &order.CompletedAt)
+	if err != nil {
+		return model.Order{}, err
+	}
+
+	if err := json.Unmarshal(lineItems, &order.LineItems); err != nil {
+		return model.Order{}, fmt.Errorf("failed to decode line items: %w", err)
+	}
+
+	return order, nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/victowork/chi-api/model"
 )
@@ -14,13 +15,31 @@ type RedisRepo struct {
 	Client *redis.Client
 }
 
+const (
+	orderNextIDKey     = "order:next_id"
+	ordersByCreatedKey = "orders:by_created"
+)
+
 func OrderIdKey(id uint64) string {
 	return fmt.Sprintf("order:%d", id)
 }
 
-func (r *RedisRepo) Insert(ctx context.Context, order model.Order) error {
-	data, err := json.Marshal(order)
+func CustomerOrdersKey(customerID uuid.UUID) string {
+	return fmt.Sprintf("customer:%s:orders", customerID)
+}
+
+func OrderStatusKey(status model.OrderStatus) string {
+	return fmt.Sprintf("orders:status:%s", status)
+}
 
+func (r *RedisRepo) Insert(ctx context.Context, order *model.Order) error {
+	id, err := r.Client.Incr(ctx, orderNextIDKey).Uint64()
+	if err != nil {
+		return fmt.Errorf("failed to generate order id: %w", err)
+	}
+	order.OrderID = id
+
+	data, err := json.Marshal(order)
 	if err != nil {
 		return fmt.Errorf("failed to decode order: %w", err)
 	}
@@ -29,26 +48,31 @@ func (r *RedisRepo) Insert(ctx context.Context, order model.Order) error {
 
 	txn := r.Client.TxPipeline()
 
-	res := r.Client.SetNX(ctx, key, string(data), 0)
+	setNXCmd := txn.SetNX(ctx, key, string(data), 0)
+	txn.SAdd(ctx, CustomerOrdersKey(order.CustomerID), key)
+	txn.ZAdd(ctx, ordersByCreatedKey, redis.Z{
+		Score:  float64(order.CreatedAt.UnixNano()),
+		Member: key,
+	})
+	txn.SAdd(ctx, OrderStatusKey(order.Status()), key)
 
-	if err = res.Err(); err != nil {
-		txn.Discard()
-		return fmt.Errorf("failed to set data in redis: %w", err)
+	if _, err := txn.Exec(ctx); err != nil {
+		return fmt.Errorf("error executing redis transaction in insert order :%w", err)
 	}
 
-	if err = r.Client.SAdd(ctx, "orders", key).Err(); err != nil {
-		txn.Discard()
-		return fmt.Errorf("failed to add redis orderid set: %w", err)
+	inserted, err := setNXCmd.Result()
+	if err != nil {
+		return fmt.Errorf("failed to set data in redis: %w", err)
 	}
-
-	if _, err := txn.Exec(ctx); err != nil {
-		return fmt.Errorf("error executing redis transaction in insert order :%w", err)
+	if !inserted {
+		return ErrAlreadyExists
 	}
 
 	return nil
 }
 
 var ErrNotExits = errors.New("order does not exists")
+var ErrAlreadyExists = errors.New("order already exists")
 
 func (r *RedisRepo) FindByID(ctx context.Context, id uint64) (model.Order, error) {
 
@@ -75,40 +99,63 @@ func (r *RedisRepo) FindByID(ctx context.Context, id uint64) (model.Order, error
 func (r *RedisRepo) DeleteByID(ctx context.Context, id uint64) error {
 	key := OrderIdKey(id)
 
+	existing, err := r.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	txn := r.Client.TxPipeline()
-	err := r.Client.Del(ctx, key).Err()
 
-	if errors.Is(err, redis.Nil) {
-		txn.Discard()
-		return ErrNotExits
-	} else if err != nil {
-		txn.Discard()
-		return fmt.Errorf("error delete order from redis: %w", err)
-	}
+	delCmd := txn.Del(ctx, key)
+	txn.SRem(ctx, CustomerOrdersKey(existing.CustomerID), key)
+	txn.ZRem(ctx, ordersByCreatedKey, key)
+	txn.SRem(ctx, OrderStatusKey(existing.Status()), key)
 
-	if err = r.Client.SRem(ctx, "orders", key).Err(); err != nil {
-		return fmt.Errorf("error deleting orderid from set: %w", err)
-	}
 	if _, err := txn.Exec(ctx); err != nil {
 		return fmt.Errorf("error executing redis transaction in delete order :%w", err)
 	}
+
+	deleted, err := delCmd.Result()
+	if err != nil {
+		return fmt.Errorf("error delete order from redis: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNotExits
+	}
+
 	return nil
 }
 
 func (r *RedisRepo) Update(ctx context.Context, order model.Order) error {
+	key := OrderIdKey(order.OrderID)
+
+	existing, err := r.FindByID(ctx, order.OrderID)
+	if err != nil {
+		return err
+	}
+
 	data, err := json.Marshal(order)
 	if err != nil {
 		return fmt.Errorf("error to encode order : %w", err)
 	}
 
-	key := OrderIdKey(order.OrderID)
-
 	err = r.Client.SetXX(ctx, key, string(data), 0).Err()
 	if errors.Is(err, redis.Nil) {
 		return ErrNotExits
 	} else if err != nil {
 		return fmt.Errorf("error updating order in redis: %w", err)
 	}
+
+	oldStatus, newStatus := existing.Status(), order.Status()
+	if oldStatus != newStatus {
+		if err := r.Client.SRem(ctx, OrderStatusKey(oldStatus), key).Err(); err != nil {
+			return fmt.Errorf("error removing order from status index: %w", err)
+		}
+		if err := r.Client.SAdd(ctx, OrderStatusKey(newStatus), key).Err(); err != nil {
+			return fmt.Errorf("error adding order to status index: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -122,8 +169,24 @@ type FindResults struct {
 	Cursor uint64
 }
 
+// FindAll walks orders:by_created by rank, so results come back
+// oldest-first by CreatedAt; orders with equal CreatedAt are ordered by
+// key string. PostgresRepo orders by order_id instead, so the two agree
+// only while IDs are issued in CreatedAt order.
 func (r *RedisRepo) FindAll(ctx context.Context, page FindAllPage) (FindResults, error) {
-	res := r.Client.SScan(ctx, "orders", page.Offset, "*", int64(page.Size))
+	return r.findAllFromZSet(ctx, ordersByCreatedKey, page)
+}
+
+func (r *RedisRepo) FindByCustomer(ctx context.Context, customerID uuid.UUID, page FindAllPage) (FindResults, error) {
+	return r.findAllFromSet(ctx, CustomerOrdersKey(customerID), page)
+}
+
+func (r *RedisRepo) FindByStatus(ctx context.Context, status model.OrderStatus, page FindAllPage) (FindResults, error) {
+	return r.findAllFromSet(ctx, OrderStatusKey(status), page)
+}
+
+func (r *RedisRepo) findAllFromSet(ctx context.Context, setKey string, page FindAllPage) (FindResults, error) {
+	res := r.Client.SScan(ctx, setKey, page.Offset, "*", int64(page.Size))
 
 	keys, cursor, err := res.Result()
 
@@ -162,3 +225,44 @@ func (r *RedisRepo) FindAll(ctx context.Context, page FindAllPage) (FindResults,
 		Cursor: cursor,
 	}, nil
 }
+
+func (r *RedisRepo) findAllFromZSet(ctx context.Context, zsetKey string, page FindAllPage) (FindResults, error) {
+	start := int64(page.Offset)
+	stop := start + int64(page.Size) - 1
+
+	keys, err := r.Client.ZRange(ctx, zsetKey, start, stop).Result()
+	if err != nil {
+		return FindResults{}, fmt.Errorf("failed to get order ids: %w", err)
+	}
+
+	if len(keys) == 0 {
+		return FindResults{
+			Orders: []model.Order{},
+		}, nil
+	}
+
+	xs, err := r.Client.MGet(ctx, keys...).Result()
+
+	if err != nil {
+		return FindResults{}, fmt.Errorf("error fetching Orders: %w", err)
+	}
+
+	orders := make([]model.Order, len(xs))
+
+	for i, x := range xs {
+		x := x.(string)
+
+		var order model.Order
+
+		err := json.Unmarshal([]byte(x), &order)
+		if err != nil {
+			return FindResults{}, fmt.Errorf("error decoding order: %w", err)
+		}
+		orders[i] = order
+	}
+
+	return FindResults{
+		Orders: orders,
+		Cursor: page.Offset + uint64(len(orders)),
+	}, nil
+}
@@ -0,0 +1,229 @@
+package order_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/victowork/chi-api/model"
+	"github.com/victowork/chi-api/repository/order"
+)
+
+func newTestRepo(t *testing.T) *order.RedisRepo {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return &order.RedisRepo{Client: client}
+}
+
+func newTestRepoWithServer(t *testing.T) (*order.RedisRepo, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return &order.RedisRepo{Client: client}, mr
+}
+
+// failingTxHook lets single commands through and fails every pipeline
+// before it is sent, so a MULTI/EXEC never reaches the server.
+type failingTxHook struct{}
+
+func (failingTxHook) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (failingTxHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook { return next }
+
+func (failingTxHook) ProcessPipelineHook(redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(context.Context, []redis.Cmder) error {
+		return errors.New("simulated redis failure")
+	}
+}
+
+func TestRedisRepo_Insert_AlreadyExists(t *testing.T) {
+	t.Parallel()
+
+	repo, mr := newTestRepoWithServer(t)
+
+	if err := mr.Set(order.OrderIdKey(1), "conflicting value"); err != nil {
+		t.Fatalf("failed to seed conflicting key: %v", err)
+	}
+
+	now := time.Now().UTC()
+	newOrder := model.Order{CustomerID: uuid.New(), CreatedAt: &now}
+
+	if err := repo.Insert(context.Background(), &newOrder); !errors.Is(err, order.ErrAlreadyExists) {
+		t.Fatalf("expected ErrAlreadyExists, got %v", err)
+	}
+}
+
+func TestRedisRepo_DeleteByID_AtomicOnTransactionFailure(t *testing.T) {
+	t.Parallel()
+
+	repo, mr := newTestRepoWithServer(t)
+
+	now := time.Now().UTC()
+	seeded := model.Order{CustomerID: uuid.New(), CreatedAt: &now}
+	if err := repo.Insert(context.Background(), &seeded); err != nil {
+		t.Fatalf("failed to seed order: %v", err)
+	}
+
+	repo.Client.AddHook(failingTxHook{})
+	err := repo.DeleteByID(context.Background(), seeded.OrderID)
+
+	if err == nil {
+		t.Fatal("expected error from failed transaction")
+	}
+
+	key := order.OrderIdKey(seeded.OrderID)
+	if !mr.Exists(key) {
+		t.Fatalf("expected order key %q to still exist after failed transaction", key)
+	}
+
+	isMember, err := mr.SIsMember(order.CustomerOrdersKey(seeded.CustomerID), key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !isMember {
+		t.Fatal("expected order to still be indexed under its customer after failed transaction")
+	}
+}
+
+func TestRedisRepo_Insert_AssignsSequentialIDs(t *testing.T) {
+	t.Parallel()
+
+	repo := newTestRepo(t)
+	now := time.Now().UTC()
+
+	for i, want := range []uint64{1, 2, 3} {
+		order := model.Order{CustomerID: uuid.New(), CreatedAt: &now}
+		if err := repo.Insert(context.Background(), &order); err != nil {
+			t.Fatalf("insert %d: unexpected error: %v", i, err)
+		}
+		if order.OrderID != want {
+			t.Fatalf("insert %d: expected order id %d, got %d", i, want, order.OrderID)
+		}
+	}
+}
+
+func TestRedisRepo_FindAll_OrdersByCreatedAt(t *testing.T) {
+	t.Parallel()
+
+	repo := newTestRepo(t)
+
+	var ids []uint64
+	for i := 0; i < 3; i++ {
+		createdAt := time.Now().UTC().Add(time.Duration(i) * time.Second)
+		seeded := model.Order{CustomerID: uuid.New(), CreatedAt: &createdAt}
+		if err := repo.Insert(context.Background(), &seeded); err != nil {
+			t.Fatalf("insert %d: unexpected error: %v", i, err)
+		}
+		ids = append(ids, seeded.OrderID)
+	}
+
+	res, err := repo.FindAll(context.Background(), order.FindAllPage{Size: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Orders) != len(ids) {
+		t.Fatalf("expected %d orders, got %d", len(ids), len(res.Orders))
+	}
+	for i, want := range ids {
+		if res.Orders[i].OrderID != want {
+			t.Fatalf("expected order %d at position %d, got %d", want, i, res.Orders[i].OrderID)
+		}
+	}
+}
+
+func TestRedisRepo_FindByCustomer(t *testing.T) {
+	t.Parallel()
+
+	repo := newTestRepo(t)
+	now := time.Now().UTC()
+
+	customerA := uuid.New()
+	customerB := uuid.New()
+
+	for _, customerID := range []uuid.UUID{customerA, customerA, customerB} {
+		order := model.Order{CustomerID: customerID, CreatedAt: &now}
+		if err := repo.Insert(context.Background(), &order); err != nil {
+			t.Fatalf("failed to seed order: %v", err)
+		}
+	}
+
+	res, err := repo.FindByCustomer(context.Background(), customerA, order.FindAllPage{Size: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Orders) != 2 {
+		t.Fatalf("expected 2 orders for customer, got %d", len(res.Orders))
+	}
+}
+
+func TestRedisRepo_FindByStatus(t *testing.T) {
+	t.Parallel()
+
+	repo := newTestRepo(t)
+	now := time.Now().UTC()
+
+	created := model.Order{CustomerID: uuid.New(), CreatedAt: &now}
+	if err := repo.Insert(context.Background(), &created); err != nil {
+		t.Fatalf("failed to seed order: %v", err)
+	}
+
+	shipped := model.Order{CustomerID: uuid.New(), CreatedAt: &now, ShippedAt: &now}
+	if err := repo.Insert(context.Background(), &shipped); err != nil {
+		t.Fatalf("failed to seed order: %v", err)
+	}
+
+	res, err := repo.FindByStatus(context.Background(), model.StatusShipped, order.FindAllPage{Size: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Orders) != 1 {
+		t.Fatalf("expected 1 shipped order, got %d", len(res.Orders))
+	}
+	if res.Orders[0].OrderID != shipped.OrderID {
+		t.Fatalf("expected order %d, got %d", shipped.OrderID, res.Orders[0].OrderID)
+	}
+}
+
+func TestRedisRepo_Update_MovesStatusIndex(t *testing.T) {
+	t.Parallel()
+
+	repo := newTestRepo(t)
+	now := time.Now().UTC()
+
+	seeded := model.Order{CustomerID: uuid.New(), CreatedAt: &now}
+	if err := repo.Insert(context.Background(), &seeded); err != nil {
+		t.Fatalf("failed to seed order: %v", err)
+	}
+
+	seeded.ShippedAt = &now
+	if err := repo.Update(context.Background(), seeded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	createdRes, err := repo.FindByStatus(context.Background(), model.StatusCreated, order.FindAllPage{Size: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(createdRes.Orders) != 0 {
+		t.Fatalf("expected order to be removed from created index, got %d", len(createdRes.Orders))
+	}
+
+	shippedRes, err := repo.FindByStatus(context.Background(), model.StatusShipped, order.FindAllPage{Size: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(shippedRes.Orders) != 1 {
+		t.Fatalf("expected order to be in shipped index, got %d", len(shippedRes.Orders))
+	}
+}
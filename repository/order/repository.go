@@ -0,0 +1,21 @@
+package order
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/victowork/chi-api/model"
+)
+
+// Repository is the storage-agnostic contract handler.Order depends on,
+// implemented by RedisRepo and PostgresRepo, and optionally wrapped by
+// CachedRepo.
+type Repository interface {
+	Insert(ctx context.Context, order *model.Order) error
+	FindByID(ctx context.Context, id uint64) (model.Order, error)
+	Update(ctx context.Context, order model.Order) error
+	DeleteByID(ctx context.Context, id uint64) error
+	FindAll(ctx context.Context, page FindAllPage) (FindResults, error)
+	FindByCustomer(ctx context.Context, customerID uuid.UUID, page FindAllPage) (FindResults, error)
+	FindByStatus(ctx context.Context, status model.OrderStatus, page FindAllPage) (FindResults, error)
+}